@@ -0,0 +1,75 @@
+// Copyright (C) 2019-2024 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package statetrie
+
+import (
+	"encoding/binary"
+
+	"github.com/algorand/go-algorand/data/basics"
+)
+
+// KeyDomain is the first byte of every state trie key. It separates the
+// keyspaces of the different ledger entities so that no two entities can ever
+// map to the same trie key.
+type KeyDomain byte
+
+// Defines known key domains. Changing a domain value is a breaking change, as
+// it changes every key (and therefore every root) of that domain.
+const (
+	// AccountKeyDomain keys are 0x01 || address (32 bytes).
+	AccountKeyDomain KeyDomain = 0x01
+	// AssetHoldingKeyDomain keys are 0x02 || asset id (8 bytes, big endian) || holder address (32 bytes).
+	AssetHoldingKeyDomain KeyDomain = 0x02
+	// BoxKeyDomain keys are 0x03 || app id (8 bytes, big endian) || box name (variable length).
+	BoxKeyDomain KeyDomain = 0x03
+)
+
+// AccountKey returns the trie key of the account with the given address.
+func AccountKey(addr basics.Address) []byte {
+	key := make([]byte, 1+len(addr))
+	key[0] = byte(AccountKeyDomain)
+	copy(key[1:], addr[:])
+	return key
+}
+
+// AssetHoldingKey returns the trie key of holder's holding of the given asset.
+// The asset id precedes the holder so that all holdings of an asset share a
+// common prefix.
+func AssetHoldingKey(aidx basics.AssetIndex, holder basics.Address) []byte {
+	key := make([]byte, 1+8+len(holder))
+	key[0] = byte(AssetHoldingKeyDomain)
+	binary.BigEndian.PutUint64(key[1:], uint64(aidx))
+	copy(key[9:], holder[:])
+	return key
+}
+
+// BoxKey returns the trie key of the named box of the given application.
+// The box name is the only variable length component and is always last, so
+// keys of distinct (app, name) pairs never collide.
+func BoxKey(appIdx basics.AppIndex, name []byte) []byte {
+	key := make([]byte, 1+8+len(name))
+	key[0] = byte(BoxKeyDomain)
+	binary.BigEndian.PutUint64(key[1:], uint64(appIdx))
+	copy(key[9:], name)
+	return key
+}
+
+// BoxKeyPrefix returns the prefix shared by the trie keys of every box of the
+// given application.
+func BoxKeyPrefix(appIdx basics.AppIndex) []byte {
+	return BoxKey(appIdx, nil)
+}
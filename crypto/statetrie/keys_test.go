@@ -0,0 +1,113 @@
+// Copyright (C) 2019-2024 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package statetrie
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/data/basics"
+	"github.com/algorand/go-algorand/test/partitiontest"
+)
+
+func TestKeyEncodings(t *testing.T) {
+	partitiontest.PartitionTest(t)
+	t.Parallel()
+
+	var addr basics.Address
+	for i := range addr {
+		addr[i] = byte(i)
+	}
+
+	// the encodings are part of the trie commitment; lock them in.
+	expected := append([]byte{0x01}, addr[:]...)
+	require.Equal(t, expected, AccountKey(addr))
+
+	expected = append([]byte{0x02, 0, 0, 0, 0, 0, 0, 0x01, 0x02}, addr[:]...)
+	require.Equal(t, expected, AssetHoldingKey(basics.AssetIndex(0x0102), addr))
+
+	expected = []byte{0x03, 0, 0, 0, 0, 0, 0, 0x01, 0x02, 'b', 'o', 'x'}
+	require.Equal(t, expected, BoxKey(basics.AppIndex(0x0102), []byte("box")))
+	require.Equal(t, expected[:9], BoxKeyPrefix(basics.AppIndex(0x0102)))
+	require.Equal(t, expected[:9], BoxKey(basics.AppIndex(0x0102), nil))
+}
+
+func TestKeyDomainsDisjoint(t *testing.T) {
+	partitiontest.PartitionTest(t)
+	t.Parallel()
+
+	var addr basics.Address
+	keys := [][]byte{
+		AccountKey(addr),
+		AssetHoldingKey(0, addr),
+		BoxKey(0, addr[:]),
+		BoxKey(0, nil),
+	}
+	for i := range keys {
+		for j := range keys {
+			if i != j {
+				require.False(t, bytes.Equal(keys[i], keys[j]), "keys %d and %d collide", i, j)
+			}
+		}
+	}
+
+	// boxes of one app never share the prefix of another app
+	require.False(t, bytes.HasPrefix(BoxKey(1, []byte("x")), BoxKeyPrefix(2)))
+	require.True(t, bytes.HasPrefix(BoxKey(1, []byte("x")), BoxKeyPrefix(1)))
+}
+
+func TestKeysCollisionFreeWithinDomain(t *testing.T) {
+	partitiontest.PartitionTest(t)
+	t.Parallel()
+
+	var h1, h2 basics.Address
+	h2[0] = 0x01
+
+	// With a variable width app id, (1, "ab") and (0x0161, "b") would both
+	// encode as 01 61 62.
+	require.NotEqual(t, BoxKey(1, []byte("ab")), BoxKey(1<<8|'a', []byte("b")))
+	require.NotEqual(t, BoxKey(1, []byte{0x02}), BoxKey(0x0102, nil))
+	require.NotEqual(t, BoxKey(0, []byte{0x01}), BoxKey(1, nil))
+
+	// adjacent asset ids and holders stay distinct
+	require.NotEqual(t, AssetHoldingKey(1, h1), AssetHoldingKey(2, h1))
+	require.NotEqual(t, AssetHoldingKey(1, h1), AssetHoldingKey(1, h2))
+	require.NotEqual(t, AssetHoldingKey(1, h2), AssetHoldingKey(0x0101, h1))
+
+	seen := make(map[string]string)
+	add := func(key []byte, desc string) {
+		if prev, ok := seen[string(key)]; ok {
+			t.Fatalf("%s collides with %s", desc, prev)
+		}
+		seen[string(key)] = desc
+	}
+	names := [][]byte{nil, {0x00}, {0x01}, {0x01, 0x00}, []byte("a"), []byte("ab"), []byte("b")}
+	ids := []uint64{0, 1, 2, 0xff, 0x100, 0x101, 1<<8 | 'a', 0x0102, 1 << 56, 1<<64 - 1}
+	for _, id := range ids {
+		for _, name := range names {
+			add(BoxKey(basics.AppIndex(id), name), fmt.Sprintf("box (%d, %x)", id, name))
+		}
+		for _, holder := range []basics.Address{h1, h2} {
+			add(AssetHoldingKey(basics.AssetIndex(id), holder), fmt.Sprintf("holding (%d, %x)", id, holder[:2]))
+		}
+		require.Len(t, AssetHoldingKey(basics.AssetIndex(id), h1), 1+8+len(h1))
+		require.Len(t, BoxKeyPrefix(basics.AppIndex(id)), 1+8)
+	}
+}
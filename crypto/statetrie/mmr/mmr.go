@@ -0,0 +1,239 @@
+// Copyright (C) 2019-2024 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package mmr
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/protocol"
+)
+
+// Errors returned by Prove and Verify.
+var (
+	ErrPosOutOfBound    = errors.New("pos out of bound")
+	ErrProofIsNil       = errors.New("proof should not be nil")
+	ErrMalformedProof   = errors.New("malformed proof")
+	ErrRootMismatch     = errors.New("root mismatch")
+	ErrEmptyAccumulator = errors.New("accumulator is empty")
+)
+
+// Accumulator is a Merkle mountain range over an append-only sequence of
+// digests (typically one state trie root per round).
+//
+// The accumulator is a list of perfect binary trees ("mountains") whose sizes
+// are the distinct powers of two in the binary representation of the number
+// of appended leaves, ordered from the tallest (oldest leaves) to the
+// shortest. Appending a leaf never changes existing nodes, so a proof stays
+// valid against the root it was issued for; proving against a newer root
+// requires a new call to Prove.
+//
+// Leaves are identified only by their index in append order. The accumulator
+// does not record which round or trie root a leaf came from: callers that
+// append one root per round starting at round r0 must prove the root of round
+// r as leaf r-r0, and look up a root's round themselves.
+//
+// . 7 leaves = 4 + 2 + 1:
+// .
+// .        p0
+// .     n       n      p1
+// .   n   n   n   n   n   n   p2
+// .   a   b   c   d   e   f   g
+// .
+// . Root = H(size || p0 || p1 || p2)
+//
+// The zero value is an empty accumulator.
+type Accumulator struct {
+	// levels[h] holds the roots of every complete subtree of height h, left to
+	// right. levels[0] holds the leaf hashes.
+	levels [][]crypto.Digest
+	size   uint64
+}
+
+// Proof shows that a leaf is the Index'th element of an accumulator of Size
+// elements.
+type Proof struct {
+	Index uint64
+	Size  uint64
+	// Path holds the siblings from the leaf up to (but not including) the
+	// peak of the mountain containing the leaf.
+	Path []crypto.Digest
+	// Peaks holds every peak of the accumulator, tallest first.
+	Peaks []crypto.Digest
+}
+
+type leaf crypto.Digest
+
+func (l leaf) ToBeHashed() (protocol.HashID, []byte) {
+	return protocol.MerkleMountainRangeLeaf, l[:]
+}
+
+type pair struct {
+	l crypto.Digest
+	r crypto.Digest
+}
+
+func (p pair) ToBeHashed() (protocol.HashID, []byte) {
+	buf := make([]byte, 2*crypto.DigestSize)
+	copy(buf[:], p.l[:])
+	copy(buf[crypto.DigestSize:], p.r[:])
+	return protocol.MerkleMountainRangeNode, buf
+}
+
+type bag struct {
+	size  uint64
+	peaks []crypto.Digest
+}
+
+func (b bag) ToBeHashed() (protocol.HashID, []byte) {
+	buf := make([]byte, 8+len(b.peaks)*crypto.DigestSize)
+	binary.BigEndian.PutUint64(buf, b.size)
+	for i := range b.peaks {
+		copy(buf[8+i*crypto.DigestSize:], b.peaks[i][:])
+	}
+	return protocol.MerkleMountainRangeRoot, buf
+}
+
+// Size returns the number of leaves appended so far.
+func (a *Accumulator) Size() uint64 {
+	return a.size
+}
+
+// Append adds d as the next leaf and returns its index.
+func (a *Accumulator) Append(d crypto.Digest) uint64 {
+	idx := a.size
+	node := crypto.HashObj(leaf(d))
+	for h := 0; ; h++ {
+		if h == len(a.levels) {
+			a.levels = append(a.levels, nil)
+		}
+		a.levels[h] = append(a.levels[h], node)
+		n := len(a.levels[h])
+		if n%2 != 0 {
+			break
+		}
+		node = crypto.HashObj(pair{l: a.levels[h][n-2], r: a.levels[h][n-1]})
+	}
+	a.size++
+	return idx
+}
+
+// peaks returns the current mountain peaks, tallest first.
+func (a *Accumulator) peaks() []crypto.Digest {
+	var peaks []crypto.Digest
+	for h := len(a.levels) - 1; h >= 0; h-- {
+		if a.size&(1<<uint(h)) != 0 {
+			peaks = append(peaks, a.levels[h][(a.size>>uint(h))-1])
+		}
+	}
+	return peaks
+}
+
+// Root returns the commitment to the accumulated sequence. The root of an
+// empty accumulator is the zero digest.
+func (a *Accumulator) Root() crypto.Digest {
+	if a.size == 0 {
+		return crypto.Digest{}
+	}
+	return crypto.HashObj(bag{size: a.size, peaks: a.peaks()})
+}
+
+// Prove returns a proof that the leaf at idx is part of the current root.
+// idx is the value Append returned for that leaf.
+func (a *Accumulator) Prove(idx uint64) (*Proof, error) {
+	if a.size == 0 {
+		return nil, ErrEmptyAccumulator
+	}
+	if idx >= a.size {
+		return nil, fmt.Errorf("idx %d >= size %d: %w", idx, a.size, ErrPosOutOfBound)
+	}
+
+	height, _ := mountainOf(idx, a.size)
+	proof := &Proof{
+		Index: idx,
+		Size:  a.size,
+		Path:  make([]crypto.Digest, height),
+		Peaks: a.peaks(),
+	}
+	for h := 0; h < height; h++ {
+		proof.Path[h] = a.levels[h][(idx>>uint(h))^1]
+	}
+	return proof, nil
+}
+
+// mountainOf returns the height of the mountain containing leaf idx in an
+// accumulator of the given size, and that mountain's position among the
+// peaks (tallest first).
+func mountainOf(idx uint64, size uint64) (height int, peak int) {
+	var start uint64
+	for h := 63; h >= 0; h-- {
+		width := uint64(1) << uint(h)
+		if size&width == 0 {
+			continue
+		}
+		if idx < start+width {
+			return h, peak
+		}
+		start += width
+		peak++
+	}
+	// unreachable for idx < size
+	return 0, peak
+}
+
+// Verify checks that proof shows d to be an element of the accumulator
+// committed to by root.
+func Verify(root crypto.Digest, d crypto.Digest, proof *Proof) error {
+	if proof == nil {
+		return ErrProofIsNil
+	}
+	if proof.Size == 0 {
+		return ErrEmptyAccumulator
+	}
+	if proof.Index >= proof.Size {
+		return fmt.Errorf("idx %d >= size %d: %w", proof.Index, proof.Size, ErrPosOutOfBound)
+	}
+
+	height, peak := mountainOf(proof.Index, proof.Size)
+	numPeaks := 0
+	for s := proof.Size; s != 0; s &= s - 1 {
+		numPeaks++
+	}
+	if len(proof.Path) != height || len(proof.Peaks) != numPeaks {
+		return fmt.Errorf("path length %d (expected %d), peaks %d (expected %d): %w",
+			len(proof.Path), height, len(proof.Peaks), numPeaks, ErrMalformedProof)
+	}
+
+	node := crypto.HashObj(leaf(d))
+	for h := 0; h < height; h++ {
+		if (proof.Index>>uint(h))&1 == 0 {
+			node = crypto.HashObj(pair{l: node, r: proof.Path[h]})
+		} else {
+			node = crypto.HashObj(pair{l: proof.Path[h], r: node})
+		}
+	}
+	if node != proof.Peaks[peak] {
+		return ErrRootMismatch
+	}
+
+	if crypto.HashObj(bag{size: proof.Size, peaks: proof.Peaks}) != root {
+		return ErrRootMismatch
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+// Copyright (C) 2019-2024 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package mmr
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand/crypto"
+	"github.com/algorand/go-algorand/test/partitiontest"
+)
+
+func roundRoot(i uint64) crypto.Digest {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], i)
+	return crypto.Hash(buf[:])
+}
+
+func TestAccumulatorProveVerify(t *testing.T) {
+	partitiontest.PartitionTest(t)
+	t.Parallel()
+
+	var a Accumulator
+	require.Equal(t, crypto.Digest{}, a.Root())
+	_, err := a.Prove(0)
+	require.ErrorIs(t, err, ErrEmptyAccumulator)
+
+	roots := make(map[crypto.Digest]bool)
+	for n := uint64(0); n < 70; n++ {
+		require.Equal(t, n, a.Append(roundRoot(n)))
+		require.Equal(t, n+1, a.Size())
+
+		root := a.Root()
+		require.False(t, roots[root], "root repeated at size %d", n+1)
+		roots[root] = true
+
+		for i := uint64(0); i <= n; i++ {
+			proof, err := a.Prove(i)
+			require.NoError(t, err)
+			require.NoError(t, Verify(root, roundRoot(i), proof))
+
+			// wrong leaf
+			require.ErrorIs(t, Verify(root, roundRoot(i+1), proof), ErrRootMismatch)
+		}
+
+		_, err = a.Prove(n + 1)
+		require.ErrorIs(t, err, ErrPosOutOfBound)
+	}
+}
+
+func TestAccumulatorRejectsTamperedProofs(t *testing.T) {
+	partitiontest.PartitionTest(t)
+	t.Parallel()
+
+	var a Accumulator
+	for n := uint64(0); n < 13; n++ {
+		a.Append(roundRoot(n))
+	}
+	root := a.Root()
+
+	require.ErrorIs(t, Verify(root, roundRoot(5), nil), ErrProofIsNil)
+
+	proof, err := a.Prove(5)
+	require.NoError(t, err)
+	require.NoError(t, Verify(root, roundRoot(5), proof))
+
+	// claim a different position
+	moved := *proof
+	moved.Index = 4
+	require.Error(t, Verify(root, roundRoot(5), &moved))
+
+	// claim a different accumulator size
+	resized := *proof
+	resized.Size = 16
+	require.ErrorIs(t, Verify(root, roundRoot(5), &resized), ErrMalformedProof)
+
+	// corrupt a path element
+	corrupt := *proof
+	corrupt.Path = append([]crypto.Digest(nil), proof.Path...)
+	corrupt.Path[0][0] ^= 1
+	require.ErrorIs(t, Verify(root, roundRoot(5), &corrupt), ErrRootMismatch)
+
+	// corrupt an unrelated peak
+	corrupt = *proof
+	corrupt.Peaks = append([]crypto.Digest(nil), proof.Peaks...)
+	corrupt.Peaks[len(corrupt.Peaks)-1][0] ^= 1
+	require.ErrorIs(t, Verify(root, roundRoot(5), &corrupt), ErrRootMismatch)
+
+	// drop a path element
+	short := *proof
+	short.Path = proof.Path[1:]
+	require.ErrorIs(t, Verify(root, roundRoot(5), &short), ErrMalformedProof)
+
+	// out of bound index
+	oob := *proof
+	oob.Index = oob.Size
+	require.ErrorIs(t, Verify(root, roundRoot(5), &oob), ErrPosOutOfBound)
+}
+
+func TestAccumulatorOldProofAgainstOldRoot(t *testing.T) {
+	partitiontest.PartitionTest(t)
+	t.Parallel()
+
+	var a Accumulator
+	for n := uint64(0); n < 6; n++ {
+		a.Append(roundRoot(n))
+	}
+	oldRoot := a.Root()
+	oldProof, err := a.Prove(2)
+	require.NoError(t, err)
+
+	for n := uint64(6); n < 20; n++ {
+		a.Append(roundRoot(n))
+	}
+
+	// proofs stay valid against the root they were issued for
+	require.NoError(t, Verify(oldRoot, roundRoot(2), oldProof))
+	require.ErrorIs(t, Verify(a.Root(), roundRoot(2), oldProof), ErrRootMismatch)
+
+	newProof, err := a.Prove(2)
+	require.NoError(t, err)
+	require.NoError(t, Verify(a.Root(), roundRoot(2), newProof))
+}
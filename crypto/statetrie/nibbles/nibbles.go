@@ -14,6 +14,8 @@
 // You should have received a copy of the GNU Affero General Public License
 // along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
 
+// Package nibbles provides the nibble (4-bit) key representation used by the
+// state trie, along with its packed and serialized forms.
 package nibbles
 
 import (
@@ -45,12 +47,9 @@ const (
 func Pack(nyb Nibbles) ([]byte, bool) {
 	length := len(nyb)
 	data := make([]byte, length/2+length%2, length/2+length%2+1)
-	for i := 0; i < length; i++ {
-		if i%2 == 0 {
-			data[i/2] = nyb[i] << 4
-		} else {
-			data[i/2] = data[i/2] | nyb[i]
-		}
+	packPairs(data[:length/2], nyb[:length&^1])
+	if length%2 != 0 {
+		data[length/2] = nyb[length-1] << 4
 	}
 
 	return data, length%2 != 0
@@ -123,39 +122,35 @@ func Deserialize(encoding []byte) (Nibbles, error) {
 		if length == 1 {
 			return nil, errors.New("invalid encoding")
 		}
-		ns = makeNibbles(encoding[:length-1], true)
+		ns = MakeNibbles(encoding[:length-1], true)
 	} else if encoding[length-1] == evenIndicator {
-		ns = makeNibbles(encoding[:length-1], false)
+		ns = MakeNibbles(encoding[:length-1], false)
 	} else {
 		return nil, errors.New("invalid encoding")
 	}
 	return ns, nil
 }
 
-// makeNibbles returns a nibble array from the byte array.  If oddLength is true,
-// the last 4 bits of the last byte of the array are ignored.
+// MakeNibbles returns a nibble array from the byte array.  If oddLength is true,
+// the last 4 bits of the last byte of the array are ignored. It is the inverse
+// of Pack.
 //
 // [0x12, 0x30], true -> [0x1, 0x2, 0x3]
 // [0x12, 0x34], false -> [0x1, 0x2, 0x3, 0x4]
 // [0x12, 0x34], true -> [0x1, 0x2, 0x3]  <-- last byte last 4 bits ignored
 // [], false -> []
-// never to be called with [], true
+// [], true -> []
 // Allocates a new byte slice.
-func makeNibbles(data []byte, oddLength bool) Nibbles {
+func MakeNibbles(data []byte, oddLength bool) Nibbles {
 	length := len(data) * 2
-	if oddLength {
+	if oddLength && length > 0 {
 		length = length - 1
 	}
 	ns := make([]byte, length)
 
-	j := 0
-	for i := 0; i < length; i++ {
-		if i%2 == 0 {
-			ns[i] = data[j] >> 4
-		} else {
-			ns[i] = data[j] & 0x0f
-			j++
-		}
+	unpackPairs(ns[:length&^1], data[:length/2])
+	if length%2 != 0 {
+		ns[length-1] = data[length/2] >> 4
 	}
 	return ns
 }
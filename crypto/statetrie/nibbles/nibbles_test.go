@@ -48,7 +48,7 @@ func TestNibblesRandom(t *testing.T) {
 		if half && localRand.Intn(2) == 0 {
 			data[len(data)-1] &= 0xf0 // sometimes clear the last nibble, sometimes do not
 		}
-		nibbles := makeNibbles(data, half)
+		nibbles := MakeNibbles(data, half)
 
 		data2 := Serialize(nibbles)
 		nibbles2, err := Deserialize(data2)
@@ -61,13 +61,13 @@ func TestNibblesRandom(t *testing.T) {
 		packed, odd := Pack(nibbles)
 		require.Equal(t, odd, half)
 		require.Equal(t, packed, data)
-		unpacked := makeNibbles(packed, odd)
+		unpacked := MakeNibbles(packed, odd)
 		require.Equal(t, nibbles, unpacked)
 
 		packed, odd = Pack(nibbles2)
 		require.Equal(t, odd, half)
 		require.Equal(t, packed, data)
-		unpacked = makeNibbles(packed, odd)
+		unpacked = MakeNibbles(packed, odd)
 		require.Equal(t, nibbles2, unpacked)
 	}
 }
@@ -130,7 +130,7 @@ func TestNibbles(t *testing.T) {
 		require.Equal(t, oddLength == (len(n)%2 == 1), true)
 		require.Equal(t, bytes.Equal(b, sampleNibblesPacked[i]), true)
 
-		unp := makeNibbles(b, oddLength)
+		unp := MakeNibbles(b, oddLength)
 		require.Equal(t, bytes.Equal(unp, n), true)
 
 	}
@@ -183,10 +183,10 @@ func TestNibbles(t *testing.T) {
 
 	makeNibblesTestExpected := Nibbles{0x0, 0x1, 0x2, 0x9, 0x2}
 	makeNibblesTestData := []byte{0x01, 0x29, 0x20}
-	mntr := makeNibbles(makeNibblesTestData, true)
+	mntr := MakeNibbles(makeNibblesTestData, true)
 	require.Equal(t, bytes.Equal(mntr, makeNibblesTestExpected), true)
 	makeNibblesTestExpectedFW := Nibbles{0x0, 0x1, 0x2, 0x9, 0x2, 0x0}
-	mntr2 := makeNibbles(makeNibblesTestData, false)
+	mntr2 := MakeNibbles(makeNibblesTestData, false)
 	require.Equal(t, bytes.Equal(mntr2, makeNibblesTestExpectedFW), true)
 
 	sampleEqualFalse := [][]Nibbles{
@@ -216,3 +216,139 @@ func TestNibbles(t *testing.T) {
 	_, e = Deserialize([]byte{0x02})
 	require.Error(t, e)
 }
+
+func TestMakeNibblesEmpty(t *testing.T) {
+	partitiontest.PartitionTest(t)
+	t.Parallel()
+
+	require.Equal(t, Nibbles{}, MakeNibbles([]byte{}, true))
+	require.Equal(t, Nibbles{}, MakeNibbles(nil, false))
+}
+
+func TestPackPairsMatchesGeneric(t *testing.T) {
+	partitiontest.PartitionTest(t)
+	t.Parallel()
+
+	// packPairs/unpackPairs may dispatch to SIMD implementations; they must
+	// agree with the generic loops for every length, including the partial
+	// block tail, and for arbitrary (not only valid nibble) input bytes.
+	localRand := rand.New(rand.NewSource(1))
+	for n := 0; n <= 130; n++ {
+		src := make([]byte, 2*n)
+		localRand.Read(src)
+		got := make([]byte, n)
+		want := make([]byte, n)
+		packPairs(got, src)
+		packPairsGeneric(want, src)
+		require.Equal(t, want, got, "pack n=%d", n)
+
+		packed := make([]byte, n)
+		localRand.Read(packed)
+		gotN := make(Nibbles, 2*n)
+		wantN := make(Nibbles, 2*n)
+		unpackPairs(gotN, packed)
+		unpackPairsGeneric(wantN, packed)
+		require.Equal(t, wantN, gotN, "unpack n=%d", n)
+	}
+}
+
+// toNibbles clamps arbitrary bytes into valid nibble values.
+func toNibbles(data []byte) Nibbles {
+	ns := make(Nibbles, len(data))
+	for i := range data {
+		ns[i] = data[i] & 0x0f
+	}
+	return ns
+}
+
+func FuzzNibblesRoundTrip(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{0x1})
+	f.Add([]byte{0x1, 0x2, 0x3})
+	f.Add([]byte{0x1, 0x2, 0x3, 0x4})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		ns := toNibbles(data)
+
+		packed, odd := Pack(ns)
+		require.Equal(t, len(ns)%2 == 1, odd)
+		require.Equal(t, ns, MakeNibbles(packed, odd))
+
+		ns2, err := Deserialize(Serialize(ns))
+		require.NoError(t, err)
+		require.Equal(t, ns, ns2)
+
+		for _, i := range []int{0, len(ns) / 2, len(ns)} {
+			require.Equal(t, ns[:i], SharedPrefix(ns, ns[:i]))
+			require.Equal(t, ns[i:], ShiftLeft(ns, i))
+		}
+	})
+}
+
+func FuzzNibblesDeserialize(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{oddIndicator})
+	f.Add([]byte{evenIndicator})
+	f.Add([]byte{0x12, 0x30, oddIndicator})
+	f.Add([]byte{0x12, 0x34, evenIndicator})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		ns, err := Deserialize(data)
+		if err != nil {
+			return
+		}
+		for _, n := range ns {
+			require.Less(t, n, byte(0x10))
+		}
+		// a successfully decoded value must survive a round trip
+		ns2, err := Deserialize(Serialize(ns))
+		require.NoError(t, err)
+		require.Equal(t, ns, ns2)
+	})
+}
+
+func benchmarkNibbles(length int) Nibbles {
+	data := make([]byte, length)
+	rand.New(rand.NewSource(1)).Read(data)
+	return toNibbles(data)
+}
+
+func BenchmarkPack(b *testing.B) {
+	ns := benchmarkNibbles(64)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Pack(ns)
+	}
+}
+
+func BenchmarkMakeNibbles(b *testing.B) {
+	packed, odd := Pack(benchmarkNibbles(64))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		MakeNibbles(packed, odd)
+	}
+}
+
+func BenchmarkSerialize(b *testing.B) {
+	ns := benchmarkNibbles(63)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Serialize(ns)
+	}
+}
+
+func BenchmarkDeserialize(b *testing.B) {
+	data := Serialize(benchmarkNibbles(63))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Deserialize(data)
+	}
+}
+
+func BenchmarkSharedPrefix(b *testing.B) {
+	ns := benchmarkNibbles(64)
+	other := append(Nibbles{}, ns...)
+	other[len(other)-1] ^= 0x1
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SharedPrefix(ns, other)
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright (C) 2019-2024 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package nibbles
+
+import (
+	"golang.org/x/sys/cpu"
+)
+
+var useAVX2 = cpu.X86.HasAVX2
+
+// packPairsAVX2 packs 2*n nibbles from src into n bytes of dst.
+// n must be a multiple of 16.
+//
+//go:noescape
+func packPairsAVX2(dst *byte, src *byte, n int)
+
+// unpackPairsAVX2 unpacks n bytes from src into 2*n nibbles of dst.
+// n must be a multiple of 16.
+//
+//go:noescape
+func unpackPairsAVX2(dst *byte, src *byte, n int)
+
+func packPairs(dst []byte, src Nibbles) {
+	if useAVX2 && len(dst) >= 16 {
+		n := len(dst) &^ 15
+		packPairsAVX2(&dst[0], &src[0], n)
+		dst, src = dst[n:], src[2*n:]
+	}
+	packPairsGeneric(dst, src)
+}
+
+func unpackPairs(dst Nibbles, src []byte) {
+	if useAVX2 && len(src) >= 16 {
+		n := len(src) &^ 15
+		unpackPairsAVX2(&dst[0], &src[0], n)
+		dst, src = dst[2*n:], src[n:]
+	}
+	unpackPairsGeneric(dst, src)
+}
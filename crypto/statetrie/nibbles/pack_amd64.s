@@ -0,0 +1,78 @@
+// Copyright (C) 2019-2024 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+#include "textflag.h"
+
+// func packPairsAVX2(dst *byte, src *byte, n int)
+//
+// Each 16-bit lane w holds a nibble pair (hi in the low byte, lo in the high
+// byte). The low byte of (w<<4 | w>>8) is hi<<4 | lo, matching the generic
+// byte arithmetic for any input.
+TEXT ·packPairsAVX2(SB), NOSPLIT, $0-24
+	MOVQ dst+0(FP), DI
+	MOVQ src+8(FP), SI
+	MOVQ n+16(FP), CX
+	VPCMPEQW Y3, Y3, Y3
+	VPSRLW   $8, Y3, Y3 // 0x00ff in every word
+
+packloop:
+	CMPQ         CX, $16
+	JB           packdone
+	VMOVDQU      (SI), Y0
+	VPSLLW       $4, Y0, Y1
+	VPSRLW       $8, Y0, Y2
+	VPOR         Y2, Y1, Y0
+	VPAND        Y3, Y0, Y0
+	VEXTRACTI128 $1, Y0, X1
+	VPACKUSWB    X1, X0, X0
+	VMOVDQU      X0, (DI)
+	ADDQ         $32, SI
+	ADDQ         $16, DI
+	SUBQ         $16, CX
+	JMP          packloop
+
+packdone:
+	VZEROUPPER
+	RET
+
+// func unpackPairsAVX2(dst *byte, src *byte, n int)
+//
+// Each source byte b is widened to a 16-bit lane and rewritten as
+// (b>>4) | (b&0x0f)<<8, which stores as the two nibbles in order.
+TEXT ·unpackPairsAVX2(SB), NOSPLIT, $0-24
+	MOVQ dst+0(FP), DI
+	MOVQ src+8(FP), SI
+	MOVQ n+16(FP), CX
+	VPCMPEQW Y3, Y3, Y3
+	VPSRLW   $12, Y3, Y3 // 0x000f in every word
+
+unpackloop:
+	CMPQ      CX, $16
+	JB        unpackdone
+	VPMOVZXBW (SI), Y0
+	VPSRLW    $4, Y0, Y1
+	VPAND     Y3, Y0, Y2
+	VPSLLW    $8, Y2, Y2
+	VPOR      Y2, Y1, Y0
+	VMOVDQU   Y0, (DI)
+	ADDQ      $16, SI
+	ADDQ      $32, DI
+	SUBQ      $16, CX
+	JMP       unpackloop
+
+unpackdone:
+	VZEROUPPER
+	RET
@@ -0,0 +1,53 @@
+// Copyright (C) 2019-2024 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package nibbles
+
+import (
+	"golang.org/x/sys/cpu"
+)
+
+var useASIMD = cpu.ARM64.HasASIMD
+
+// packPairsNEON packs 2*n nibbles from src into n bytes of dst.
+// n must be a multiple of 16.
+//
+//go:noescape
+func packPairsNEON(dst *byte, src *byte, n int)
+
+// unpackPairsNEON unpacks n bytes from src into 2*n nibbles of dst.
+// n must be a multiple of 16.
+//
+//go:noescape
+func unpackPairsNEON(dst *byte, src *byte, n int)
+
+func packPairs(dst []byte, src Nibbles) {
+	if useASIMD && len(dst) >= 16 {
+		n := len(dst) &^ 15
+		packPairsNEON(&dst[0], &src[0], n)
+		dst, src = dst[n:], src[2*n:]
+	}
+	packPairsGeneric(dst, src)
+}
+
+func unpackPairs(dst Nibbles, src []byte) {
+	if useASIMD && len(src) >= 16 {
+		n := len(src) &^ 15
+		unpackPairsNEON(&dst[0], &src[0], n)
+		dst, src = dst[2*n:], src[n:]
+	}
+	unpackPairsGeneric(dst, src)
+}
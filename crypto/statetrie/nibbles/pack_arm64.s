@@ -0,0 +1,61 @@
+// Copyright (C) 2019-2024 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+
+#include "textflag.h"
+
+// func packPairsNEON(dst *byte, src *byte, n int)
+//
+// VLD2 de-interleaves the nibble pairs into high (V0) and low (V1) halves.
+TEXT ·packPairsNEON(SB), NOSPLIT, $0-24
+	MOVD dst+0(FP), R0
+	MOVD src+8(FP), R1
+	MOVD n+16(FP), R2
+
+packloop:
+	CMP    $16, R2
+	BLT    packdone
+	VLD2.P 32(R1), [V0.B16, V1.B16]
+	VSHL   $4, V0.B16, V0.B16
+	VORR   V1.B16, V0.B16, V0.B16
+	VST1.P [V0.B16], 16(R0)
+	SUB    $16, R2
+	B      packloop
+
+packdone:
+	RET
+
+// func unpackPairsNEON(dst *byte, src *byte, n int)
+//
+// VST2 re-interleaves the high (V1) and low (V2) nibbles of each byte.
+TEXT ·unpackPairsNEON(SB), NOSPLIT, $0-24
+	MOVD dst+0(FP), R0
+	MOVD src+8(FP), R1
+	MOVD n+16(FP), R2
+	VMOVI $15, V3.B16
+
+unpackloop:
+	CMP    $16, R2
+	BLT    unpackdone
+	VLD1.P 16(R1), [V0.B16]
+	VUSHR  $4, V0.B16, V1.B16
+	VAND   V3.B16, V0.B16, V2.B16
+	VST2.P [V1.B16, V2.B16], 32(R0)
+	SUB    $16, R2
+	B      unpackloop
+
+unpackdone:
+	RET
@@ -0,0 +1,34 @@
+// Copyright (C) 2019-2024 Algorand, Inc.
+// This file is part of go-algorand
+//
+// go-algorand is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// go-algorand is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with go-algorand.  If not, see <https://www.gnu.org/licenses/>.
+
+package nibbles
+
+// packPairsGeneric packs src two nibbles per byte into dst.
+// len(src) must be 2*len(dst).
+func packPairsGeneric(dst []byte, src Nibbles) {
+	for i := range dst {
+		dst[i] = src[2*i]<<4 | src[2*i+1]
+	}
+}
+
+// unpackPairsGeneric splits each byte of src into two nibbles of dst.
+// len(dst) must be 2*len(src).
+func unpackPairsGeneric(dst Nibbles, src []byte) {
+	for i, b := range src {
+		dst[2*i] = b >> 4
+		dst[2*i+1] = b & 0x0f
+	}
+}
@@ -47,6 +47,9 @@ const (
 	KeysInMSS                        HashID = "KP"
 	MerkleArrayNode                  HashID = "MA"
 	MerkleVectorCommitmentBottomLeaf HashID = "MB"
+	MerkleMountainRangeLeaf          HashID = "MML"
+	MerkleMountainRangeNode          HashID = "MMN"
+	MerkleMountainRangeRoot          HashID = "MMR"
 	Message                          HashID = "MX"
 	NetIdentityChallenge             HashID = "NIC"
 	NetIdentityChallengeResponse     HashID = "NIR"